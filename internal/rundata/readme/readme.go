@@ -0,0 +1,140 @@
+// Package readme parses the rundata that a featureprofiles test package's
+// README.md declares, via either the legacy `# XX-1.1: Description` heading
+// or a YAML front-matter block. It is shared by the addrundata CLI and the
+// rundataanalyzer go/analysis Analyzer so the two never diverge on what a
+// README.md means.
+package readme
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Data is the rundata a README.md declares.
+type Data struct {
+	TestPlanID      string
+	TestDescription string
+	TestUUID        string
+	Metadata        *Metadata
+}
+
+// Metadata mirrors rundata.Metadata and carries the richer, optional
+// fields that can only come from a README.md YAML front-matter block.
+type Metadata struct {
+	Authors         []string `json:"authors,omitempty"`
+	References      []string `json:"references,omitempty"`
+	Platforms       []string `json:"platforms,omitempty"`
+	OCPathsRequired []string `json:"ocPathsRequired,omitempty"`
+	OCRPCsRequired  []string `json:"ocRPCsRequired,omitempty"`
+	IssueTrackers   []string `json:"issueTrackers,omitempty"`
+}
+
+// IsZero reports whether md has no fields set, in which case it should not
+// be emitted at all.
+func (md *Metadata) IsZero() bool {
+	return md == nil ||
+		(len(md.Authors) == 0 &&
+			len(md.References) == 0 &&
+			len(md.Platforms) == 0 &&
+			len(md.OCPathsRequired) == 0 &&
+			len(md.OCRPCsRequired) == 0 &&
+			len(md.IssueTrackers) == 0)
+}
+
+// headingRE matches the heading line: `# XX-1.1: Foo Functional Test`
+var headingRE = regexp.MustCompile(`#(.*?):(.*)`)
+
+// frontMatter is the YAML front-matter representation of a README.md's
+// rundata metadata. sigs.k8s.io/yaml normalizes YAML to JSON before
+// unmarshaling, so this struct (and every consumer of it) only ever has to
+// deal with JSON-compatible types, regardless of how the front matter was
+// authored.
+type frontMatter struct {
+	TestPlanID      string   `json:"testPlanID"`
+	TestDescription string   `json:"testDescription"`
+	TestUUID        string   `json:"testUUID,omitempty"`
+	Authors         []string `json:"authors,omitempty"`
+	References      []string `json:"references,omitempty"`
+	Platforms       []string `json:"platforms,omitempty"`
+	OCPathsRequired []string `json:"ocPathsRequired,omitempty"`
+	OCRPCsRequired  []string `json:"ocRPCsRequired,omitempty"`
+	IssueTrackers   []string `json:"issueTrackers,omitempty"`
+}
+
+// data converts the front matter into a *Data.
+func (fm *frontMatter) data() *Data {
+	d := &Data{
+		TestPlanID:      fm.TestPlanID,
+		TestDescription: fm.TestDescription,
+		TestUUID:        fm.TestUUID,
+	}
+	md := &Metadata{
+		Authors:         fm.Authors,
+		References:      fm.References,
+		Platforms:       fm.Platforms,
+		OCPathsRequired: fm.OCPathsRequired,
+		OCRPCsRequired:  fm.OCRPCsRequired,
+		IssueTrackers:   fm.IssueTrackers,
+	}
+	if !md.IsZero() {
+		d.Metadata = md
+	}
+	return d
+}
+
+// Parse reads Data from a README.md. If the file begins with a
+// `---`-delimited YAML front-matter block, that block is parsed for the
+// full set of rundata fields; otherwise the legacy
+// `# XX-1.1: Description` heading is used, as before.
+func Parse(r io.Reader) (*Data, error) {
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("missing markdown heading")
+	}
+	if strings.TrimSpace(sc.Text()) == "---" {
+		return parseFrontMatter(sc)
+	}
+	return parseHeading(sc.Text())
+}
+
+// parseHeading parses the legacy `# XX-1.1: Description` heading line.
+func parseHeading(line string) (*Data, error) {
+	m := headingRE.FindStringSubmatch(line)
+	if len(m) < 3 {
+		return nil, fmt.Errorf("cannot parse markdown: %s", line)
+	}
+	return &Data{
+		TestPlanID:      strings.TrimSpace(m[1]),
+		TestDescription: strings.TrimSpace(m[2]),
+	}, nil
+}
+
+// parseFrontMatter parses a YAML front-matter block, having already
+// consumed its opening `---` line from sc.
+func parseFrontMatter(sc *bufio.Scanner) (*Data, error) {
+	var buf strings.Builder
+	for sc.Scan() {
+		if strings.TrimSpace(sc.Text()) == "---" {
+			var fm frontMatter
+			if err := yaml.Unmarshal([]byte(buf.String()), &fm); err != nil {
+				return nil, fmt.Errorf("cannot parse front matter: %w", err)
+			}
+			return fm.data(), nil
+		}
+		buf.WriteString(sc.Text())
+		buf.WriteString("\n")
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("front matter block was not terminated")
+}
@@ -0,0 +1,46 @@
+// Package rundata holds the package-level variables that a featureprofiles
+// test package's generated rundata_test.go populates from its README.md:
+// the test's plan ID, description, UUID, and optional metadata.
+package rundata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// TestPlanID, TestDescription, and TestUUID identify the test plan this
+// package implements. They are set by the generated rundata_test.go.
+var (
+	TestPlanID      string
+	TestDescription string
+	TestUUID        string
+)
+
+// TestMetadata holds the optional, richer rundata fields that can only
+// come from a README.md YAML front-matter block.
+var TestMetadata Metadata
+
+// Metadata mirrors the fields a README.md's YAML front-matter block can
+// declare for a test.
+type Metadata struct {
+	Authors         []string
+	References      []string
+	Platforms       []string
+	OCPathsRequired []string
+	OCRPCsRequired  []string
+	IssueTrackers   []string
+}
+
+// CanonicalUUID reports the canonical string form of s, which may carry a
+// "urn:uuid:" prefix, as long as it is a valid v4 UUID. It is the single
+// source of truth for what counts as a valid TestUUID, shared by the
+// addrundata CLI and the rundata go/analysis Analyzer.
+func CanonicalUUID(s string) (string, error) {
+	u, err := uuid.Parse(strings.TrimPrefix(s, "urn:uuid:"))
+	if err != nil || u.Version() != 4 {
+		return "", fmt.Errorf("%q is not a valid v4 UUID", s)
+	}
+	return u.String(), nil
+}
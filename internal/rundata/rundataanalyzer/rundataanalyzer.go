@@ -0,0 +1,220 @@
+// Package rundataanalyzer provides a go/analysis Analyzer that checks a
+// test package's rundata.TestPlanID/TestDescription/TestUUID assignments
+// against the sibling README.md, and offers SuggestedFixes to correct
+// drift. It is the analysis-framework equivalent of the addrundata CLI's
+// check/fix commands, and can be driven by singlechecker, multichecker, or
+// an editor such as gopls.
+package rundataanalyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/openconfig/featureprofiles/internal/rundata"
+	"github.com/openconfig/featureprofiles/internal/rundata/readme"
+)
+
+const doc = `check rundata against README.md
+
+The rundata analyzer reports test packages whose rundata.TestPlanID,
+rundata.TestDescription, or rundata.TestUUID assignments (in func init())
+have drifted from the package's README.md, and suggests fixes that
+rewrite the offending string literals or insert a freshly minted UUIDv4.`
+
+// Analyzer is the rundata consistency checker.
+var Analyzer = &analysis.Analyzer{
+	Name:     "rundata",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// readREADME reads the rundata a package's README.md declares, via the
+// shared readme package, which understands both the legacy
+// `# XX-1.1: Description` heading and a YAML front-matter block.
+func readREADME(dir string) (*readme.Data, error) {
+	path := filepath.Join(dir, "README.md")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d, err := readme.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return d, nil
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if len(pass.Files) == 0 {
+		return nil, nil
+	}
+	dir := filepath.Dir(pass.Fset.Position(pass.Files[0].Pos()).Filename)
+	want, err := readREADME(dir)
+	if err != nil {
+		// No (parseable) README.md alongside this package: not a
+		// featureprofiles test package, so there is nothing to check.
+		return nil, nil
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil)}
+
+	var foundRundataInit bool
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		fd := n.(*ast.FuncDecl)
+		if fd.Name.Name != "init" || fd.Recv != nil {
+			return
+		}
+		if checkInit(pass, fd, want) {
+			foundRundataInit = true
+		}
+	})
+	if !foundRundataInit {
+		reportMissingInit(pass, want)
+	}
+	return nil, nil
+}
+
+// checkInit inspects a single func init() for rundata assignments, reports
+// diagnostics for any that have drifted from want, and reports whether fd
+// was a rundata init() at all.
+func checkInit(pass *analysis.Pass, fd *ast.FuncDecl, want *readme.Data) bool {
+	lits := map[string]*ast.BasicLit{}
+	for _, stmt := range fd.Body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		sel, ok := assign.Lhs[0].(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || pkg.Name != "rundata" {
+			continue
+		}
+		lit, ok := assign.Rhs[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			continue
+		}
+		switch sel.Sel.Name {
+		case "TestPlanID", "TestDescription", "TestUUID":
+			lits[sel.Sel.Name] = lit
+		}
+	}
+	if len(lits) == 0 {
+		return false
+	}
+
+	reportLiteral := func(field, got, want string) {
+		pass.Report(analysis.Diagnostic{
+			Pos:     lits[field].Pos(),
+			End:     lits[field].End(),
+			Message: fmt.Sprintf("rundata.%s is %q, want %q (from README.md)", field, got, want),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message:   fmt.Sprintf("Update rundata.%s to match README.md", field),
+				TextEdits: []analysis.TextEdit{{Pos: lits[field].Pos(), End: lits[field].End(), NewText: []byte(strconv.Quote(want))}},
+			}},
+		})
+	}
+
+	for field, want := range map[string]string{
+		"TestPlanID":      want.TestPlanID,
+		"TestDescription": want.TestDescription,
+	} {
+		lit, ok := lits[field]
+		if !ok {
+			continue
+		}
+		got, err := strconv.Unquote(lit.Value)
+		if err != nil || got == want {
+			continue
+		}
+		reportLiteral(field, got, want)
+	}
+
+	if lit, ok := lits["TestUUID"]; ok {
+		got, err := strconv.Unquote(lit.Value)
+		if err == nil {
+			_, err = rundata.CanonicalUUID(got)
+		}
+		if err != nil {
+			newUUID := uuid.NewString()
+			pass.Report(analysis.Diagnostic{
+				Pos:     lit.Pos(),
+				End:     lit.End(),
+				Message: "rundata.TestUUID is missing or malformed",
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message:   "Insert a freshly minted UUID",
+					TextEdits: []analysis.TextEdit{{Pos: lit.Pos(), End: lit.End(), NewText: []byte(strconv.Quote(newUUID))}},
+				}},
+			})
+		}
+	}
+	return true
+}
+
+// rundataImportPath is the import path of the package whose TestPlanID,
+// TestDescription, and TestUUID variables the generated func init() sets.
+const rundataImportPath = "github.com/openconfig/featureprofiles/internal/rundata"
+
+// hasRundataImport reports whether file already imports the rundata
+// package, whether by its real import path or (as in this package's own
+// testdata) a stand-in with the same base name.
+func hasRundataImport(file *ast.File) bool {
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if path == "rundata" || strings.HasSuffix(path, "/rundata") {
+			return true
+		}
+	}
+	return false
+}
+
+// reportMissingInit reports a package that has no rundata func init() at
+// all, with a fix that appends a freshly generated one and, if the file
+// does not already import rundata, adds that import too.
+func reportMissingInit(pass *analysis.Pass, want *readme.Data) {
+	file := pass.Files[0]
+	newInit := fmt.Sprintf(`
+func init() {
+	rundata.TestPlanID = %q
+	rundata.TestDescription = %q
+	rundata.TestUUID = %q
+}
+`, want.TestPlanID, want.TestDescription, uuid.NewString())
+
+	edits := []analysis.TextEdit{{Pos: file.End(), End: file.End(), NewText: []byte(newInit)}}
+	if !hasRundataImport(file) {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     file.Name.End(),
+			End:     file.Name.End(),
+			NewText: []byte(fmt.Sprintf("\n\nimport %q", rundataImportPath)),
+		})
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     file.End(),
+		Message: "package is missing a rundata func init()",
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "Insert a func init() populated from README.md",
+			TextEdits: edits,
+		}},
+	})
+}
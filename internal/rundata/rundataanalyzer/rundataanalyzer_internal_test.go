@@ -0,0 +1,69 @@
+package rundataanalyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/openconfig/featureprofiles/internal/rundata/readme"
+)
+
+// TestReportMissingInit exercises reportMissingInit directly, since the
+// analysistest-driven TestAnalyzer only checks the reported diagnostics,
+// not the generated SuggestedFix text, and a func init() with a freshly
+// minted UUID is not something a golden file can match exactly.
+func TestReportMissingInit(t *testing.T) {
+	want := &readme.Data{TestPlanID: "XX-1.1", TestDescription: "Foo Functional Test"}
+	importRE := regexp.MustCompile(`import "github\.com/openconfig/featureprofiles/internal/rundata"`)
+
+	tests := []struct {
+		name       string
+		src        string
+		wantImport bool
+	}{{
+		name:       "no rundata import",
+		src:        "package p\n\nfunc unrelated() {}\n",
+		wantImport: true,
+	}, {
+		name:       "already imports rundata",
+		src:        "package p\n\nimport \"github.com/openconfig/featureprofiles/internal/rundata\"\n\nfunc unrelated() { _ = rundata.TestPlanID }\n",
+		wantImport: false,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "test.go", test.src, 0)
+			if err != nil {
+				t.Fatalf("ParseFile: %v", err)
+			}
+
+			var diags []analysis.Diagnostic
+			pass := &analysis.Pass{
+				Fset:  fset,
+				Files: []*ast.File{file},
+				Report: func(d analysis.Diagnostic) {
+					diags = append(diags, d)
+				},
+			}
+			reportMissingInit(pass, want)
+
+			if len(diags) != 1 {
+				t.Fatalf("got %d diagnostics, want 1", len(diags))
+			}
+			var gotImport bool
+			for _, edit := range diags[0].SuggestedFixes[0].TextEdits {
+				if importRE.Match(edit.NewText) {
+					gotImport = true
+				}
+			}
+			if gotImport != test.wantImport {
+				t.Errorf("suggested fix adds rundata import = %v, want %v", gotImport, test.wantImport)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package rundataanalyzer_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/openconfig/featureprofiles/internal/rundata/rundataanalyzer"
+)
+
+func TestAnalyzer(t *testing.T) {
+	// "a" has a rundata func init() that has drifted from README.md in
+	// every field; "b" has no rundata func init() at all; "c" has a
+	// well-formed but non-v4 rundata.TestUUID.
+	analysistest.Run(t, analysistest.TestData(), rundataanalyzer.Analyzer, "a", "b", "c")
+}
@@ -0,0 +1,11 @@
+// Package a is rundataanalyzer test data: its rundata has drifted from
+// README.md in every field.
+package a
+
+import "rundata"
+
+func init() {
+	rundata.TestPlanID = "YY-1.1"              // want `rundata\.TestPlanID is "YY-1\.1", want "XX-1\.1" \(from README\.md\)`
+	rundata.TestDescription = "Wrong summary"  // want `rundata\.TestDescription is "Wrong summary", want "Foo Functional Test" \(from README\.md\)`
+	rundata.TestUUID = "not-a-uuid"            // want "rundata.TestUUID is missing or malformed"
+}
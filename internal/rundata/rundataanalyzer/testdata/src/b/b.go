@@ -0,0 +1,5 @@
+// Package b is rundataanalyzer test data: it has no rundata func init() at
+// all, and does not import rundata either.
+package b
+
+func unrelated() {} // want "package is missing a rundata func init\\(\\)"
@@ -0,0 +1,11 @@
+// Package c is rundataanalyzer test data: its rundata.TestUUID is a
+// well-formed UUID, but not a v4 one.
+package c
+
+import "rundata"
+
+func init() {
+	rundata.TestPlanID = "ZZ-3.1"
+	rundata.TestDescription = "Baz Functional Test"
+	rundata.TestUUID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8" // want "rundata.TestUUID is missing or malformed"
+}
@@ -0,0 +1,10 @@
+// Package rundata is a stand-in for github.com/openconfig/featureprofiles/internal/rundata,
+// used only so that testdata/src/a and testdata/src/c can type-check under analysistest's
+// GOPATH-style loader.
+package rundata
+
+var (
+	TestPlanID      string
+	TestDescription string
+	TestUUID        string
+)
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/google/uuid"
+
+	"github.com/openconfig/featureprofiles/internal/rundata"
+)
+
+// testcase is one featureprofiles test package: its README.md, the rundata
+// its rundata_test.go currently declares (if any), and, once fix has run,
+// the rundata it should declare.
+type testcase struct {
+	pkg      string
+	markdown *parsedData
+	existing *parsedData
+	fixed    *parsedData
+}
+
+// packageRE matches a source file's package clause.
+var packageRE = regexp.MustCompile(`^package\s+(\w+)`)
+
+// read populates tc from the README.md, rundata_test.go (if present), and
+// package name of the featureprofiles test package in dir.
+func (tc *testcase) read(dir string) error {
+	readme, err := os.Open(filepath.Join(dir, "README.md"))
+	if err != nil {
+		return err
+	}
+	md, err := parseMarkdown(readme)
+	readme.Close()
+	if err != nil {
+		return err
+	}
+	tc.markdown = md
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, path := range matches {
+		if filepath.Base(path) == "rundata_test.go" {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			existing, err := parseCode(f)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("cannot parse rundata_test.go: %w", err)
+			}
+			tc.existing = existing
+			continue
+		}
+		if tc.pkg == "" {
+			pkg, err := readPackageName(path)
+			if err != nil {
+				return err
+			}
+			tc.pkg = pkg
+		}
+	}
+	return nil
+}
+
+// readPackageName reads the package clause of the Go source file at path.
+func readPackageName(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if m := packageRE.FindStringSubmatch(sc.Text()); m != nil {
+			return m[1], nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s: missing package clause", path)
+}
+
+// check reports a missing tc.markdown or tc.existing, a TestPlanID or
+// TestDescription in tc.existing that differs from tc.markdown, and a
+// TestUUID that is not a valid v4 UUID. The metadata is not compared.
+func (tc *testcase) check() []error {
+	var errs []error
+	if tc.markdown == nil {
+		errs = append(errs, errors.New("missing or unparseable README.md"))
+	}
+	if tc.existing == nil {
+		errs = append(errs, errors.New("missing rundata_test.go"))
+		return errs
+	}
+	if tc.markdown != nil {
+		if tc.existing.testPlanID != tc.markdown.testPlanID {
+			errs = append(errs, fmt.Errorf("rundata.TestPlanID %q does not match README.md %q", tc.existing.testPlanID, tc.markdown.testPlanID))
+		}
+		if tc.existing.testDescription != tc.markdown.testDescription {
+			errs = append(errs, fmt.Errorf("rundata.TestDescription %q does not match README.md %q", tc.existing.testDescription, tc.markdown.testDescription))
+		}
+	}
+	if _, err := rundata.CanonicalUUID(tc.existing.testUUID); err != nil {
+		errs = append(errs, fmt.Errorf("rundata.TestUUID %q is not a valid v4 UUID", tc.existing.testUUID))
+	}
+	return errs
+}
+
+// fix computes tc.fixed from tc.markdown, reusing tc.existing's testUUID if
+// it is already a valid v4 UUID and minting a fresh one otherwise.
+func (tc *testcase) fix() error {
+	testUUID := ""
+	if tc.existing != nil {
+		testUUID = tc.existing.testUUID
+	}
+	canonical, err := rundata.CanonicalUUID(testUUID)
+	if err != nil {
+		canonical = uuid.NewString()
+	}
+	tc.fixed = &parsedData{
+		testPlanID:      tc.markdown.testPlanID,
+		testDescription: tc.markdown.testDescription,
+		testUUID:        canonical,
+		metadata:        tc.markdown.metadata,
+	}
+	return nil
+}
+
+// write generates rundata_test.go from tc.fixed into dir.
+func (tc *testcase) write(dir string) error {
+	f, err := os.Create(filepath.Join(dir, "rundata_test.go"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return tc.fixed.write(f, tc.pkg)
+}
@@ -271,3 +271,44 @@ func TestCase_Write(t *testing.T) {
 		t.Errorf("Write then read output differs -want,+got:\n%s", diff)
 	}
 }
+
+func TestCase_Write_Metadata(t *testing.T) {
+	const frontMatterText = `---
+testPlanID: XX-2.1
+testDescription: Description with metadata
+authors:
+  - alice
+platforms:
+  - CISCO
+---
+`
+	var want, got testcase
+
+	testdir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(testdir, "README.md"), []byte(frontMatterText), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(testdir, "foo_test.go"), []byte(testCode), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read, fix, and write.
+	if err := want.read(testdir); err != nil {
+		t.Fatal(err)
+	}
+	if err := want.fix(); err != nil {
+		t.Fatal(err)
+	}
+	if err := want.write(testdir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read it back to ensure the metadata from the front matter survived
+	// the fix -> write -> read round trip.
+	if err := got.read(testdir); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want.fixed.metadata, got.existing.metadata); diff != "" {
+		t.Errorf("metadata did not survive write then read -want,+got:\n%s", diff)
+	}
+}
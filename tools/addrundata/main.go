@@ -0,0 +1,111 @@
+// Command addrundata checks (and, with -fix, rewrites) the rundata_test.go
+// generated for each featureprofiles test package, keeping
+// rundata.TestPlanID, rundata.TestDescription, and rundata.TestUUID in
+// sync with the package's README.md.
+//
+// Usage:
+//
+//	addrundata [-fix] [-list] [-run regexp] [-skip regexp] [dir...]
+//
+// Without -fix, addrundata reports every test case whose rundata_test.go
+// has drifted from its README.md and exits non-zero. With -fix, it
+// rewrites rundata_test.go to match instead. With -list, it prints the
+// testPlanIDs -run and -skip select, without reading, checking, or fixing
+// any of them. dir defaults to the current directory.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/openconfig/featureprofiles/tools/addrundata/testmatch"
+)
+
+var (
+	fixFlag  = flag.Bool("fix", false, "rewrite rundata_test.go files that do not match README.md")
+	listFlag = flag.Bool("list", false, "print the testPlanIDs of the selected test cases instead of checking or fixing them")
+	runFlag  = flag.String("run", "", "only process test cases whose testPlanID or feature directory matches this regexp")
+	skipFlag = flag.String("skip", "", "skip test cases whose testPlanID or feature directory matches this regexp")
+)
+
+func main() {
+	flag.Parse()
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	matcher, err := testmatch.New(*runFlag, *skipFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var failed bool
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			return processDir(path, matcher, &failed)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// processDir processes path as a test case if it contains a README.md,
+// applying matcher to its testPlanID and directory before doing any of the
+// more expensive read/check/fix/write work.
+func processDir(path string, matcher *testmatch.Matcher, failed *bool) error {
+	f, err := os.Open(filepath.Join(path, "README.md"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	md, err := parseMarkdown(f)
+	f.Close()
+	if err != nil {
+		// Not a (parseable) featureprofiles test package: nothing to do.
+		return nil
+	}
+
+	if !matcher.Match(md.testPlanID, path) {
+		return nil
+	}
+	if *listFlag {
+		fmt.Println(md.testPlanID)
+		return nil
+	}
+
+	var tc testcase
+	if err := tc.read(path); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if *fixFlag {
+		if err := tc.fix(); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		return tc.write(path)
+	}
+
+	for _, checkErr := range tc.check() {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, checkErr)
+		*failed = true
+	}
+	return nil
+}
@@ -9,34 +9,34 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+
+	"github.com/openconfig/featureprofiles/internal/rundata/readme"
 )
 
 type parsedData struct {
 	testPlanID      string
 	testDescription string
 	testUUID        string
+	metadata        *metadata
 }
 
-// markdownRE matches the heading line: `# XX-1.1: Foo Functional Test`
-var markdownRE = regexp.MustCompile(`#(.*?):(.*)`)
+// metadata mirrors rundata.Metadata and carries the richer, optional
+// fields that can only come from a README.md YAML front-matter block.
+type metadata = readme.Metadata
 
-// parseMarkdown reads parsedData from README.md
+// parseMarkdown reads parsedData from README.md, via the shared readme
+// package, which understands both the legacy `# XX-1.1: Description`
+// heading and a `---`-delimited YAML front-matter block.
 func parseMarkdown(r io.Reader) (*parsedData, error) {
-	sc := bufio.NewScanner(r)
-	if !sc.Scan() {
-		if err := sc.Err(); err != nil {
-			return nil, err
-		}
-		return nil, errors.New("missing markdown heading")
-	}
-	line := sc.Text()
-	m := markdownRE.FindStringSubmatch(line)
-	if len(m) < 3 {
-		return nil, fmt.Errorf("cannot parse markdown: %s", line)
+	d, err := readme.Parse(r)
+	if err != nil {
+		return nil, err
 	}
 	return &parsedData{
-		testPlanID:      strings.TrimSpace(m[1]),
-		testDescription: strings.TrimSpace(m[2]),
+		testPlanID:      d.TestPlanID,
+		testDescription: d.TestDescription,
+		testUUID:        d.TestUUID,
+		metadata:        d.Metadata,
 	}, nil
 }
 
@@ -66,6 +66,17 @@ func parseCode(r io.Reader) (*parsedData, error) {
 // rundataRE matches a line like this: `  rundata.TestUUID = "..."`
 var rundataRE = regexp.MustCompile(`\s+rundata\.(\w+) = (".*")`)
 
+// metadataStartRE matches the start of a `rundata.TestMetadata =
+// rundata.Metadata{` literal.
+var metadataStartRE = regexp.MustCompile(`\s+rundata\.TestMetadata = rundata\.Metadata\{`)
+
+// metadataFieldRE matches a single field line inside a `rundata.Metadata{`
+// literal, e.g. `	Authors: []string{"a", "b"},`.
+var metadataFieldRE = regexp.MustCompile(`^\s*(\w+):\s*\[\]string(?:\(nil\)|\{(.*)\}),?\s*$`)
+
+// quotedStringRE matches one quoted Go string literal.
+var quotedStringRE = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
 // parseInit parses the rundata from the body of func init().
 func (pd *parsedData) parseInit(sc *bufio.Scanner) error {
 	for sc.Scan() {
@@ -73,6 +84,14 @@ func (pd *parsedData) parseInit(sc *bufio.Scanner) error {
 		if line == "}" {
 			return nil
 		}
+		if metadataStartRE.MatchString(line) {
+			md, err := parseMetadataLiteral(sc)
+			if err != nil {
+				return err
+			}
+			pd.metadata = md
+			continue
+		}
 		m := rundataRE.FindStringSubmatch(line)
 		if len(m) < 3 {
 			continue
@@ -94,6 +113,45 @@ func (pd *parsedData) parseInit(sc *bufio.Scanner) error {
 	return errors.New("func init() was not terminated")
 }
 
+// parseMetadataLiteral parses the body of a `rundata.Metadata{...}` literal,
+// having already consumed its opening line from sc.
+func parseMetadataLiteral(sc *bufio.Scanner) (*metadata, error) {
+	md := new(metadata)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "}" {
+			return md, nil
+		}
+		m := metadataFieldRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		var vals []string
+		for _, q := range quotedStringRE.FindAllString(m[2], -1) {
+			v, err := strconv.Unquote(q)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse metadata line: %s: %w", line, err)
+			}
+			vals = append(vals, v)
+		}
+		switch m[1] {
+		case "Authors":
+			md.Authors = vals
+		case "References":
+			md.References = vals
+		case "Platforms":
+			md.Platforms = vals
+		case "OCPathsRequired":
+			md.OCPathsRequired = vals
+		case "OCRPCsRequired":
+			md.OCRPCsRequired = vals
+		case "IssueTrackers":
+			md.IssueTrackers = vals
+		}
+	}
+	return nil, errors.New("rundata.Metadata literal was not terminated")
+}
+
 var tmpl = template.Must(template.New("rundata_test.go").Parse(
 	`// Code generated by go run tools/addrundata; DO NOT EDIT.
 package {{.Package}}
@@ -102,14 +160,28 @@ import "github.com/openconfig/featureprofiles/internal/rundata"
 
 func init() {
 {{range .Data}}	rundata.{{.Key}} = {{printf "%q\n" .Value}}{{end -}}
+{{with .Metadata}}	rundata.TestMetadata = rundata.Metadata{
+		Authors:         {{printf "%#v" .Authors}},
+		References:      {{printf "%#v" .References}},
+		Platforms:       {{printf "%#v" .Platforms}},
+		OCPathsRequired: {{printf "%#v" .OCPathsRequired}},
+		OCRPCsRequired:  {{printf "%#v" .OCRPCsRequired}},
+		IssueTrackers:   {{printf "%#v" .IssueTrackers}},
+	}
+{{end -}}
 }
 `))
 
 // write generates a complete rundata_test.go to the writer.
 func (pd *parsedData) write(w io.Writer, pkg string) error {
+	var md *metadata
+	if !pd.metadata.IsZero() {
+		md = pd.metadata
+	}
 	tmpl.Execute(w, &struct {
-		Package string
-		Data    []struct{ Key, Value string }
+		Package  string
+		Data     []struct{ Key, Value string }
+		Metadata *metadata
 	}{
 		Package: pkg,
 		Data: []struct{ Key, Value string }{
@@ -117,6 +189,7 @@ func (pd *parsedData) write(w io.Writer, pkg string) error {
 			{"TestDescription", pd.testDescription},
 			{"TestUUID", pd.testUUID},
 		},
+		Metadata: md,
 	})
 	return nil
 }
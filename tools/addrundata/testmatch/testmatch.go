@@ -0,0 +1,101 @@
+// Package testmatch selects featureprofiles test cases by testPlanID or
+// feature directory path, modeled on the `-run`/`-skip` flags accepted by
+// `go test`.
+package testmatch
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pattern holds both compilations of a single -run/-skip pattern: the whole
+// pattern, for matching a directory path, and its "/"-separated parts, for
+// matching a testPlanID with subtest semantics.
+type pattern struct {
+	whole *regexp.Regexp
+	parts []*regexp.Regexp
+}
+
+func compilePattern(p string) (*pattern, error) {
+	whole, err := regexp.Compile(p)
+	if err != nil {
+		return nil, fmt.Errorf("bad pattern %q: %w", p, err)
+	}
+	fields := strings.Split(p, "/")
+	parts := make([]*regexp.Regexp, len(fields))
+	for i, f := range fields {
+		re, err := regexp.Compile(f)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern %q: %w", p, err)
+		}
+		parts[i] = re
+	}
+	return &pattern{whole: whole, parts: parts}, nil
+}
+
+// matchesTestPlanID applies go test's subtest matching semantics: testPlanID
+// is split on "/" and compared component-by-component against p's parts, up
+// to the shorter of the two. A pattern with more components than
+// testPlanID (e.g. "RT-5.1/IPv4" against the top-level testPlanID
+// "RT-5.1") still matches, since every provided component matched;
+// testPlanID components beyond len(p.parts) are likewise ignored.
+func (p *pattern) matchesTestPlanID(testPlanID string) bool {
+	fields := strings.Split(testPlanID, "/")
+	n := len(p.parts)
+	if len(fields) < n {
+		n = len(fields)
+	}
+	for i := 0; i < n; i++ {
+		if !p.parts[i].MatchString(fields[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesDir reports whether dir matches p as a plain, whole-string regexp.
+func (p *pattern) matchesDir(dir string) bool {
+	return p.whole.MatchString(dir)
+}
+
+// Matcher decides whether a test case should be processed, based on a
+// `-run` pattern that it must match and a `-skip` pattern that it must not
+// match. Either pattern may be absent, in which case it imposes no
+// constraint.
+type Matcher struct {
+	run  *pattern
+	skip *pattern
+}
+
+// New compiles run and skip into a Matcher. An empty string leaves the
+// corresponding constraint unset.
+func New(run, skip string) (*Matcher, error) {
+	m := new(Matcher)
+	var err error
+	if run != "" {
+		if m.run, err = compilePattern(run); err != nil {
+			return nil, fmt.Errorf("-run: %w", err)
+		}
+	}
+	if skip != "" {
+		if m.skip, err = compilePattern(skip); err != nil {
+			return nil, fmt.Errorf("-skip: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// Match reports whether the test case identified by testPlanID (e.g.
+// "RT-5.1") and located in the feature directory dir should be processed.
+// It matches testPlanID and dir independently against each pattern; either
+// one matching is enough to count as a match for that pattern.
+func (m *Matcher) Match(testPlanID, dir string) bool {
+	if m.run != nil && !m.run.matchesTestPlanID(testPlanID) && !m.run.matchesDir(dir) {
+		return false
+	}
+	if m.skip != nil && (m.skip.matchesTestPlanID(testPlanID) || m.skip.matchesDir(dir)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package testmatch
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		desc       string
+		run, skip  string
+		testPlanID string
+		dir        string
+		want       bool
+	}{{
+		desc:       "no patterns matches everything",
+		testPlanID: "RT-5.1",
+		dir:        "feature/routing/ate_tests/rt_5_1",
+		want:       true,
+	}, {
+		desc:       "run matches testPlanID prefix",
+		run:        `RT-5\..*`,
+		testPlanID: "RT-5.1",
+		dir:        "feature/routing/ate_tests/rt_5_1",
+		want:       true,
+	}, {
+		desc:       "run does not match",
+		run:        `RT-5\..*`,
+		testPlanID: "RT-6.1",
+		dir:        "feature/routing/ate_tests/rt_6_1",
+		want:       false,
+	}, {
+		desc:       "run matches directory path instead of testPlanID",
+		run:        `.*rt_5_1`,
+		testPlanID: "RT-5.1",
+		dir:        "feature/routing/ate_tests/rt_5_1",
+		want:       true,
+	}, {
+		desc:       "subtest pattern matches top-level testPlanID",
+		run:        `RT-5\.1/IPv4`,
+		testPlanID: "RT-5.1",
+		dir:        "feature/routing/ate_tests/rt_5_1",
+		want:       true,
+	}, {
+		desc:       "subtest pattern does not match a different top level",
+		run:        `RT-5\.2/IPv4`,
+		testPlanID: "RT-5.1",
+		dir:        "feature/routing/ate_tests/rt_5_1",
+		want:       false,
+	}, {
+		desc:       "skip excludes a match",
+		skip:       `RT-5\..*`,
+		testPlanID: "RT-5.1",
+		dir:        "feature/routing/ate_tests/rt_5_1",
+		want:       false,
+	}, {
+		desc:       "skip leaves non-matches alone",
+		skip:       `RT-5\..*`,
+		testPlanID: "RT-6.1",
+		dir:        "feature/routing/ate_tests/rt_6_1",
+		want:       true,
+	}, {
+		desc:       "run and skip combine",
+		run:        `RT-.*`,
+		skip:       `RT-5\..*`,
+		testPlanID: "RT-6.1",
+		dir:        "feature/routing/ate_tests/rt_6_1",
+		want:       true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			m, err := New(test.run, test.skip)
+			if err != nil {
+				t.Fatalf("New(%q, %q) failed: %v", test.run, test.skip, err)
+			}
+			if got := m.Match(test.testPlanID, test.dir); got != test.want {
+				t.Errorf("Match(%q, %q) = %v, want %v", test.testPlanID, test.dir, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewBadPattern(t *testing.T) {
+	if _, err := New(`[`, ""); err == nil {
+		t.Error("New with bad -run pattern got nil error, want non-nil")
+	}
+	if _, err := New("", `[`); err == nil {
+		t.Error("New with bad -skip pattern got nil error, want non-nil")
+	}
+}
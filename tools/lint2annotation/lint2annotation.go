@@ -2,9 +2,13 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -16,9 +20,17 @@ type diag struct {
 
 type jsonOutput map[string]map[string][]diag
 
+var (
+	format   = flag.String("format", "actions", "output format: actions, sarif, or text")
+	baseline = flag.String("baseline", "", "path to a prior SARIF file; diagnostics already present there (matched by ruleId, uri, startLine, and message) are suppressed. Only applies to -format=sarif")
+)
+
 func main() {
-	outfile := os.Args[1]
-	outBytes, err := os.ReadFile(outfile)
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: lint2annotation [-format=actions|sarif|text] [-baseline=file] <diagnostics.json>")
+	}
+	outBytes, err := os.ReadFile(flag.Arg(0))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -27,12 +39,246 @@ func main() {
 	if err := json.Unmarshal(outBytes, &out); err != nil {
 		log.Fatal(err)
 	}
+
+	switch *format {
+	case "actions":
+		writeActions(os.Stdout, out)
+	case "text":
+		writeText(os.Stdout, out)
+	case "sarif":
+		seen, err := loadBaseline(*baseline)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := writeSARIF(os.Stdout, out, seen); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("unknown -format %q", *format)
+	}
+}
+
+// writeActions prints GitHub Actions `::error` workflow command annotations.
+func writeActions(w io.Writer, out jsonOutput) {
 	for _, pkg := range out {
 		for _, diags := range pkg {
-			for _, diag := range diags {
-				pos := strings.Split(diag.Posn, ":")
-				fmt.Printf("::error file=%s,line=%s,col=%s::%s\n", pos[0], pos[1], pos[2], diag.Message)
+			for _, d := range diags {
+				pos := strings.Split(d.Posn, ":")
+				fmt.Fprintf(w, "::error file=%s,line=%s,col=%s::%s\n", pos[0], pos[1], pos[2], d.Message)
+			}
+		}
+	}
+}
+
+// writeText prints one human-readable line per diagnostic.
+func writeText(w io.Writer, out jsonOutput) {
+	for _, pkg := range out {
+		for analyzer, diags := range pkg {
+			for _, d := range diags {
+				fmt.Fprintf(w, "%s: [%s] %s\n", d.Posn, analyzer, d.Message)
+			}
+		}
+	}
+}
+
+// SARIF 2.1.0 types, limited to the fields this converter populates. See
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// buildSARIF converts out into a SARIF log with one run per analyzer, so
+// GitHub code scanning, GitLab, and other SARIF consumers can group results
+// by tool.
+func buildSARIF(out jsonOutput) *sarifLog {
+	type perAnalyzer struct {
+		rules   map[string]bool
+		results []sarifResult
+	}
+	byAnalyzer := map[string]*perAnalyzer{}
+
+	for _, pkg := range out {
+		for analyzer, diags := range pkg {
+			a := byAnalyzer[analyzer]
+			if a == nil {
+				a = &perAnalyzer{rules: map[string]bool{}}
+				byAnalyzer[analyzer] = a
+			}
+			for _, d := range diags {
+				ruleID := d.Category
+				if ruleID == "" {
+					ruleID = analyzer
+				}
+				a.rules[ruleID] = true
+				uri, line, col := splitPosn(d.Posn)
+				a.results = append(a.results, sarifResult{
+					RuleID:  ruleID,
+					Level:   "error",
+					Message: sarifMessage{Text: d.Message},
+					Locations: []sarifLocation{{
+						PhysicalLocation: sarifPhysicalLocation{
+							ArtifactLocation: sarifArtifactLocation{URI: uri},
+							Region:           sarifRegion{StartLine: line, StartColumn: col},
+						},
+					}},
+				})
+			}
+		}
+	}
+
+	analyzers := make([]string, 0, len(byAnalyzer))
+	for analyzer := range byAnalyzer {
+		analyzers = append(analyzers, analyzer)
+	}
+	sort.Strings(analyzers)
+
+	log := &sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+	}
+	for _, analyzer := range analyzers {
+		a := byAnalyzer[analyzer]
+		rules := make([]sarifRule, 0, len(a.rules))
+		for id := range a.rules {
+			rules = append(rules, sarifRule{ID: id})
+		}
+		sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+		log.Runs = append(log.Runs, sarifRun{
+			Tool:    sarifTool{Driver: sarifDriver{Name: analyzer, Rules: rules}},
+			Results: a.results,
+		})
+	}
+	return log
+}
+
+// splitPosn splits a diag.Posn of the form "file:line:col" into its parts.
+func splitPosn(posn string) (uri string, line, col int) {
+	parts := strings.Split(posn, ":")
+	uri = parts[0]
+	if len(parts) > 1 {
+		line, _ = strconv.Atoi(parts[1])
+	}
+	if len(parts) > 2 {
+		col, _ = strconv.Atoi(parts[2])
+	}
+	return uri, line, col
+}
+
+// baselineKey identifies a diagnostic for the purposes of baseline
+// suppression.
+type baselineKey struct {
+	ruleID  string
+	uri     string
+	line    int
+	message string
+}
+
+// loadBaseline reads a prior SARIF file and returns the set of diagnostics
+// it already contains. path may be empty, in which case no diagnostics are
+// suppressed.
+func loadBaseline(path string) (map[baselineKey]bool, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+	var prior sarifLog
+	if err := json.Unmarshal(b, &prior); err != nil {
+		return nil, fmt.Errorf("parsing baseline: %w", err)
+	}
+	seen := map[baselineKey]bool{}
+	for _, run := range prior.Runs {
+		for _, r := range run.Results {
+			if len(r.Locations) == 0 {
+				continue
+			}
+			loc := r.Locations[0].PhysicalLocation
+			seen[baselineKey{
+				ruleID:  r.RuleID,
+				uri:     loc.ArtifactLocation.URI,
+				line:    loc.Region.StartLine,
+				message: r.Message.Text,
+			}] = true
+		}
+	}
+	return seen, nil
+}
+
+// writeSARIF writes out as a SARIF log, suppressing any diagnostic already
+// present in seen.
+func writeSARIF(w io.Writer, out jsonOutput, seen map[baselineKey]bool) error {
+	log := buildSARIF(out)
+	if seen != nil {
+		for i, run := range log.Runs {
+			kept := make([]sarifResult, 0, len(run.Results))
+			for _, r := range run.Results {
+				loc := r.Locations[0].PhysicalLocation
+				key := baselineKey{
+					ruleID:  r.RuleID,
+					uri:     loc.ArtifactLocation.URI,
+					line:    loc.Region.StartLine,
+					message: r.Message.Text,
+				}
+				if seen[key] {
+					continue
+				}
+				kept = append(kept, r)
 			}
+			log.Runs[i].Results = kept
 		}
 	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
 }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func sampleOutput() jsonOutput {
+	return jsonOutput{
+		"example.com/foo": {
+			"rundata": []diag{
+				{Category: "rundata", Posn: "foo_test.go:3:2", Message: "bad TestPlanID"},
+				{Category: "rundata", Posn: "foo_test.go:4:2", Message: "bad TestDescription"},
+			},
+			"unused": []diag{
+				{Posn: "foo_test.go:10:1", Message: "unused variable x"},
+			},
+		},
+	}
+}
+
+func TestWriteActions(t *testing.T) {
+	var buf bytes.Buffer
+	writeActions(&buf, sampleOutput())
+	got := buf.String()
+	for _, want := range []string{
+		"::error file=foo_test.go,line=3,col=2::bad TestPlanID\n",
+		"::error file=foo_test.go,line=4,col=2::bad TestDescription\n",
+		"::error file=foo_test.go,line=10,col=1::unused variable x\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeActions output missing %q; got:\n%s", want, got)
+		}
+	}
+}
+
+func TestBuildSARIF(t *testing.T) {
+	log := buildSARIF(sampleOutput())
+	if len(log.Runs) != 2 {
+		t.Fatalf("got %d runs, want 2", len(log.Runs))
+	}
+	for _, run := range log.Runs {
+		switch run.Tool.Driver.Name {
+		case "rundata":
+			if len(run.Results) != 2 {
+				t.Errorf("rundata run got %d results, want 2", len(run.Results))
+			}
+			if len(run.Tool.Driver.Rules) != 1 || run.Tool.Driver.Rules[0].ID != "rundata" {
+				t.Errorf("rundata run got rules %+v, want [{rundata}]", run.Tool.Driver.Rules)
+			}
+		case "unused":
+			if len(run.Results) != 1 {
+				t.Errorf("unused run got %d results, want 1", len(run.Results))
+			}
+			if got := run.Results[0].RuleID; got != "unused" {
+				t.Errorf("unused diag with no Category got ruleId %q, want %q (fallback to analyzer name)", got, "unused")
+			}
+		default:
+			t.Errorf("unexpected run %q", run.Tool.Driver.Name)
+		}
+	}
+}
+
+func TestWriteSARIFBaseline(t *testing.T) {
+	var baselineBuf bytes.Buffer
+	if err := writeSARIF(&baselineBuf, sampleOutput(), nil); err != nil {
+		t.Fatalf("writeSARIF(baseline): %v", err)
+	}
+
+	// Re-running against the same output with that SARIF as a baseline
+	// should suppress every diagnostic.
+	seen, err := loadBaselineBytes(t, baselineBuf.Bytes())
+	if err != nil {
+		t.Fatalf("loadBaseline: %v", err)
+	}
+	var gotBuf bytes.Buffer
+	if err := writeSARIF(&gotBuf, sampleOutput(), seen); err != nil {
+		t.Fatalf("writeSARIF(filtered): %v", err)
+	}
+	var got sarifLog
+	if err := json.Unmarshal(gotBuf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshaling output: %v", err)
+	}
+	for _, run := range got.Runs {
+		if len(run.Results) != 0 {
+			t.Errorf("run %q still has %d results after baseline suppression, want 0", run.Tool.Driver.Name, len(run.Results))
+		}
+	}
+}
+
+// loadBaselineBytes is loadBaseline, but from an in-memory SARIF log
+// instead of a file, for TestWriteSARIFBaseline.
+func loadBaselineBytes(t *testing.T, b []byte) (map[baselineKey]bool, error) {
+	t.Helper()
+	path := t.TempDir() + "/baseline.sarif"
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return loadBaseline(path)
+}
@@ -0,0 +1,16 @@
+// Command rundataanalyzer runs the rundata consistency checker as a
+// standalone go/analysis checker, so it can be driven by `go vet -vettool`,
+// invoked directly for its `-json` output (which tools/lint2annotation
+// turns into CI annotations), or wired into gopls for in-editor quick
+// fixes.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/openconfig/featureprofiles/internal/rundata/rundataanalyzer"
+)
+
+func main() {
+	singlechecker.Main(rundataanalyzer.Analyzer)
+}